@@ -3,39 +3,305 @@ package main
 import (
 	"flag"
 	"fmt"
+	"os"
+
+	"github.com/0dayfall/minifuture/pkg/backtest"
+	"github.com/0dayfall/minifuture/pkg/calc"
+	"github.com/0dayfall/minifuture/pkg/fixedpoint"
+	"github.com/0dayfall/minifuture/pkg/fx"
+	"github.com/0dayfall/minifuture/pkg/instrument"
+	"github.com/0dayfall/minifuture/pkg/portfolio"
+	"github.com/0dayfall/minifuture/pkg/pricing"
+	"github.com/0dayfall/minifuture/pkg/tui"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "backtest":
+			runBacktest(os.Args[2:])
+			return
+		case "tui":
+			runTUI(os.Args[2:])
+			return
+		case "portfolio":
+			runPortfolio(os.Args[2:])
+			return
+		}
+	}
+	runSize(os.Args[1:])
+}
+
+func runSize(args []string) {
+	fs := flag.NewFlagSet("size", flag.ExitOnError)
+
+	price := fs.String("price", "0", "Price of a stock")
+	risk := fs.String("risk", "0", "Risk")
+	stop := fs.String("stop", "0", "Stop")
+	financeLevel := fs.String("financeLevel", "0", "Finance Level")
+	derivatePrice := fs.String("derivatePrice", "0", "Derivate Price")
+	lotSize := fs.String("lotSize", "1", "Smallest tradeable number of shares")
+	tickSize := fs.String("tickSize", "0.01", "Smallest price increment for the derivative")
+	symbol := fs.String("symbol", "", "Stock symbol to fetch the current price for, e.g. AAPL")
+	derivateSymbol := fs.String("derivateSymbol", "", "Derivative symbol to fetch the current bid/ask for, e.g. MINILONG123")
+	instrumentPath := fs.String("instrument", "", "Path to a JSON instrument config (parityRatio, quoteCurrency, financeCurrency, multiplier); defaults to the classic 8.34 same-currency parity")
+	fxRateFlag := fs.Float64("fxRate", 0, "Manual FX rate from financeCurrency to quoteCurrency; required when the instrument's two currencies differ")
+
+	fs.Parse(args)
+
+	riskV, err := fixedpoint.NewFromString(*risk)
+	exitOnErr(err, "risk")
+	priceV, err := fixedpoint.NewFromString(*price)
+	exitOnErr(err, "price")
+	stopV, err := fixedpoint.NewFromString(*stop)
+	exitOnErr(err, "stop")
+	financeLevelV, err := fixedpoint.NewFromString(*financeLevel)
+	exitOnErr(err, "financeLevel")
+	derivatePriceV, err := fixedpoint.NewFromString(*derivatePrice)
+	exitOnErr(err, "derivatePrice")
+	lotSizeV, err := fixedpoint.NewFromString(*lotSize)
+	exitOnErr(err, "lotSize")
+	tickSizeV, err := fixedpoint.NewFromString(*tickSize)
+	exitOnErr(err, "tickSize")
+
+	inst := defaultInstrument()
+	if *instrumentPath != "" {
+		loaded, err := instrument.Load(*instrumentPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid -instrument: %v\n", err)
+			os.Exit(1)
+		}
+		inst = loaded
+	}
+
+	fxRate, err := resolveFXRate(inst, *fxRateFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	if *symbol != "" || *derivateSymbol != "" {
+		provider := pricing.NewYahooQuoteProvider()
+
+		if *symbol != "" {
+			quote, err := provider.GetQuote(*symbol)
+			if err != nil {
+				fmt.Printf("Stock> Could not fetch quote for %s, falling back to -price: %v\n", *symbol, err)
+			} else {
+				priceV = fixedpoint.NewFromFloat(pricing.FallbackPrice(quote, priceV.Float64()))
+			}
+		}
+
+		if *derivateSymbol != "" {
+			quote, err := provider.GetQuote(*derivateSymbol)
+			if err != nil {
+				fmt.Printf("Mini> Could not fetch quote for %s, falling back to -derivatePrice: %v\n", *derivateSymbol, err)
+			} else {
+				derivatePriceV = fixedpoint.NewFromFloat(pricing.FallbackPrice(quote, derivatePriceV.Float64()))
+			}
+		}
+	}
+
+	numberOfStocks := calc.NumberOfStocks(riskV, priceV, stopV, lotSizeV)
+	fmt.Printf("Stock> Number of stocks: %s\n", numberOfStocks)
+
+	takeProfit := calc.TakeProfit(priceV, stopV)
+	fmt.Printf("Stock> Take profit: %s\n", takeProfit)
+
+	leverage := calc.MiniLeverage(priceV, financeLevelV, inst, fxRate)
+	fmt.Printf("Mini> Leverage: %s\n", leverage)
+
+	miniStop := calc.MiniStop(stopV, financeLevelV, tickSizeV, inst, fxRate)
+	fmt.Printf("Mini> Stop: %s\n", miniStop)
+
+	howMany := calc.MiniQuantity(riskV, derivatePriceV, miniStop, lotSizeV)
+	fmt.Printf("Mini> How many can I buy: %s\n", howMany)
+
+	miniProfit := calc.MiniProfit(takeProfit, financeLevelV, derivatePriceV, howMany, inst, fxRate)
+	fmt.Printf("Mini> Profit: %s\n", miniProfit)
+}
+
+func exitOnErr(err error, flagName string) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid -%s: %v\n", flagName, err)
+		os.Exit(1)
+	}
+}
+
+// runBacktest replays historical bars through the sizing formulas and
+// prints a SessionSymbolReport.
+func runBacktest(args []string) {
+	fs := flag.NewFlagSet("backtest", flag.ExitOnError)
 
-	/*var price float64
-	var risk float64
-	var stop float64
-	var financeLevel float64
-	var derivatePrice float64*/
+	symbol := fs.String("symbol", "", "Symbol the bars belong to, for the report header")
+	barsPath := fs.String("bars", "", "Path to a CSV file of time,open,high,low,close bars")
+	initialBalance := fs.String("initialBalance", "10000", "Starting account balance")
+	risk := fs.String("risk", "100", "Risk budget per trade")
+	stopDistancePct := fs.String("stopDistancePct", "0.02", "Stop distance below entry, as a fraction of price")
+	lotSize := fs.String("lotSize", "1", "Smallest tradeable number of mini-future units")
+	tickSize := fs.String("tickSize", "0.01", "Smallest price increment for the derivative")
+	feePerTrade := fs.String("feePerTrade", "0", "Fee charged per simulated trade")
+	instrumentPath := fs.String("instrument", "", "Path to a JSON instrument config; defaults to the classic 8.34 same-currency parity")
+	fxRateFlag := fs.Float64("fxRate", 0, "Manual FX rate from financeCurrency to quoteCurrency")
 
-	price := flag.Float64("price", 0, "Price of a stock")
-	risk := flag.Float64("risk", 0, "Risk")
-	stop := flag.Float64("stop", 0, "Stop")
-	financeLevel := flag.Float64("financeLevel", 0, "Finance Level")
-	derivatePrice := flag.Float64("derivatePrice", 0, "Derivate Price")
+	fs.Parse(args)
 
-	flag.Parse()
+	if *barsPath == "" {
+		fmt.Fprintln(os.Stderr, "backtest: -bars is required")
+		os.Exit(1)
+	}
 
-	numberOfStocks := *risk / (*price - *stop)
-	fmt.Printf("Stock> Number of stocks: %f\n", numberOfStocks)
+	bars, err := backtest.LoadBarsCSV(*barsPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
 
-	takeProfit := *price + 2*(*price-*stop)
-	fmt.Printf("Stock> Take profit: %f\n", takeProfit)
+	inst := defaultInstrument()
+	if *instrumentPath != "" {
+		loaded, err := instrument.Load(*instrumentPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid -instrument: %v\n", err)
+			os.Exit(1)
+		}
+		inst = loaded
+	}
 
-	paritet := (*price - *financeLevel) / 100 * 8.34
-	fmt.Printf("Mini> Leverage: %f\n", paritet)
+	fxRate, err := resolveFXRate(inst, *fxRateFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
 
-	miniStop := (*stop - *financeLevel) / 100 * 8.34
-	fmt.Printf("Mini> Stop: %f\n", miniStop)
+	cfg := backtest.Config{Instrument: inst, FXRate: fxRate}
+	cfg.InitialBalance, err = fixedpoint.NewFromString(*initialBalance)
+	exitOnErr(err, "initialBalance")
+	cfg.Risk, err = fixedpoint.NewFromString(*risk)
+	exitOnErr(err, "risk")
+	cfg.StopDistancePct, err = fixedpoint.NewFromString(*stopDistancePct)
+	exitOnErr(err, "stopDistancePct")
+	cfg.LotSize, err = fixedpoint.NewFromString(*lotSize)
+	exitOnErr(err, "lotSize")
+	cfg.TickSize, err = fixedpoint.NewFromString(*tickSize)
+	exitOnErr(err, "tickSize")
+	cfg.FeePerTrade, err = fixedpoint.NewFromString(*feePerTrade)
+	exitOnErr(err, "feePerTrade")
 
-	howMany := *risk / (*derivatePrice - miniStop)
-	fmt.Printf("Mini> How many can I buy: %f\n", howMany)
+	report, err := backtest.Run(*symbol, bars, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "backtest: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Backtest> Symbol: %s\n", report.Symbol)
+	fmt.Printf("Backtest> Initial balance: %s\n", report.InitialBalance)
+	fmt.Printf("Backtest> Final balance: %s\n", report.FinalBalance)
+	fmt.Printf("Backtest> Total fees: %s\n", report.TotalFees)
+	fmt.Printf("Backtest> Trades: %d\n", report.NumTrades)
+	fmt.Printf("Backtest> Win rate: %.2f%%\n", report.WinRate*100)
+	fmt.Printf("Backtest> Avg R-multiple: %.2f\n", report.AvgRMultiple)
+	fmt.Printf("Backtest> Max drawdown: %s\n", report.MaxDrawdown)
+	fmt.Printf("Backtest> Sharpe: %.2f\n", report.Sharpe)
+}
+
+// runTUI starts the interactive scenario explorer, seeded from flags so a
+// user can launch straight into a familiar starting point.
+func runTUI(args []string) {
+	fs := flag.NewFlagSet("tui", flag.ExitOnError)
+
+	price := fs.String("price", "100", "Starting price of a stock")
+	stop := fs.String("stop", "95", "Starting stop")
+	risk := fs.String("risk", "1000", "Starting risk")
+	financeLevel := fs.String("financeLevel", "80", "Starting finance level")
+	parity := fs.Float64("parity", 8.34, "Starting parity ratio")
+	derivatePrice := fs.String("derivatePrice", "16.5", "Starting derivative price")
+
+	fs.Parse(args)
+
+	priceV, err := fixedpoint.NewFromString(*price)
+	exitOnErr(err, "price")
+	stopV, err := fixedpoint.NewFromString(*stop)
+	exitOnErr(err, "stop")
+	riskV, err := fixedpoint.NewFromString(*risk)
+	exitOnErr(err, "risk")
+	financeLevelV, err := fixedpoint.NewFromString(*financeLevel)
+	exitOnErr(err, "financeLevel")
+	derivatePriceV, err := fixedpoint.NewFromString(*derivatePrice)
+	exitOnErr(err, "derivatePrice")
+
+	model := tui.NewModel(priceV, stopV, riskV, financeLevelV, fixedpoint.NewFromFloat(*parity), derivatePriceV)
+	if err := tui.Run(model); err != nil {
+		fmt.Fprintf(os.Stderr, "tui: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runPortfolio allocates a total risk budget across the legs described in a
+// YAML config file and prints a consolidated sizing table.
+func runPortfolio(args []string) {
+	fs := flag.NewFlagSet("portfolio", flag.ExitOnError)
+
+	configPath := fs.String("config", "", "Path to a YAML portfolio config (riskBudget + legs)")
+	strategyName := fs.String("strategy", "equal-risk", "Allocation strategy: equal-risk, kelly-fraction, or weighted")
+
+	fs.Parse(args)
+
+	if *configPath == "" {
+		fmt.Fprintln(os.Stderr, "portfolio: -config is required")
+		os.Exit(1)
+	}
+
+	cfg, err := portfolio.LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	strategy, err := portfolio.StrategyByName(*strategyName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	totalRisk, err := fixedpoint.NewFromString(cfg.RiskBudget)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "portfolio: invalid riskBudget: %v\n", err)
+		os.Exit(1)
+	}
+
+	plan, err := portfolio.Build(cfg.Legs, totalRisk, strategy)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "portfolio: %v\n", err)
+		os.Exit(1)
+	}
+
+	portfolio.WriteReport(os.Stdout, plan)
+}
+
+// defaultInstrument reproduces the tool's original behavior: a single
+// same-currency 8.34 parity ratio, for users who don't pass -instrument.
+func defaultInstrument() *instrument.Instrument {
+	return &instrument.Instrument{
+		ParityRatio:     8.34,
+		QuoteCurrency:   "SEK",
+		FinanceCurrency: "SEK",
+		Multiplier:      1,
+	}
+}
 
-	miniProfit := (((takeProfit - *financeLevel) / 100 * 8.34) * howMany) - (*derivatePrice * howMany)
-	fmt.Printf("Mini> Profit: %f\n", miniProfit)
+// resolveFXRate returns the rate to convert an amount in inst.FinanceCurrency
+// into inst.QuoteCurrency, preferring an explicit -fxRate override.
+func resolveFXRate(inst *instrument.Instrument, fxRateFlag float64) (float64, error) {
+	if fxRateFlag > 0 {
+		return fxRateFlag, nil
+	}
+	if inst.QuoteCurrency == inst.FinanceCurrency {
+		return 1, nil
+	}
+	rates := fx.NewStaticRateProvider(nil)
+	rate, err := rates.Rate(inst.FinanceCurrency, inst.QuoteCurrency)
+	if err != nil {
+		return 0, fmt.Errorf("resolving FX rate for %s/%s: %w (pass -fxRate to override)", inst.FinanceCurrency, inst.QuoteCurrency, err)
+	}
+	return rate, nil
 }
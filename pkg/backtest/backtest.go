@@ -0,0 +1,191 @@
+// Package backtest replays historical OHLC bars for an underlying stock and
+// simulates entering mini-future positions sized by pkg/calc's risk
+// formulas, so the sizing rules can be validated on real price series
+// before trading them live.
+package backtest
+
+import (
+	"math"
+
+	"github.com/0dayfall/minifuture/pkg/calc"
+	"github.com/0dayfall/minifuture/pkg/fixedpoint"
+	"github.com/0dayfall/minifuture/pkg/instrument"
+)
+
+// Config controls how the simulation sizes and manages each trade.
+type Config struct {
+	Instrument      *instrument.Instrument
+	FXRate          float64
+	InitialBalance  fixedpoint.Value
+	Risk            fixedpoint.Value
+	StopDistancePct fixedpoint.Value // e.g. 0.02 for a 2% stop below entry
+	LotSize         fixedpoint.Value
+	TickSize        fixedpoint.Value
+	FeePerTrade     fixedpoint.Value
+}
+
+// Trade is one simulated round trip: enter at the signal bar's close, then
+// exit at either the stop or the take-profit, whichever the following bars'
+// price path touches first. Trades never overlap; the next signal is only acted
+// on once the prior trade has exited.
+type Trade struct {
+	EntryTime  Bar
+	EntryPrice fixedpoint.Value
+	StopPrice  fixedpoint.Value
+	TakeProfit fixedpoint.Value
+	MiniQty    fixedpoint.Value
+	ExitPrice  fixedpoint.Value
+	PnL        fixedpoint.Value
+	RMultiple  float64
+	Win        bool
+}
+
+// SessionSymbolReport summarizes a full backtest run over one symbol,
+// mirroring the shape of bbgo's backtest report: balances, fees and
+// aggregate trade statistics.
+type SessionSymbolReport struct {
+	Symbol         string
+	InitialBalance fixedpoint.Value
+	FinalBalance   fixedpoint.Value
+	TotalFees      fixedpoint.Value
+	NumTrades      int
+	WinRate        float64
+	AvgRMultiple   float64
+	MaxDrawdown    fixedpoint.Value
+	Sharpe         float64
+	Trades         []Trade
+}
+
+// Run simulates entering a new mini-future position on a bar close only
+// while flat (no position already open), holding until the stop or
+// take-profit is hit by a later bar, sized by the same risk formulas the
+// live CLI uses. Trades never overlap, so balance and drawdown reflect a
+// single position's worth of capital at a time.
+func Run(symbol string, bars []Bar, cfg Config) (*SessionSymbolReport, error) {
+	balance := cfg.InitialBalance
+	peak := balance
+	maxDrawdown := fixedpoint.Zero
+	var totalFees fixedpoint.Value
+	var trades []Trade
+	var rSum float64
+	var returns []float64
+
+	for i := 0; i < len(bars)-1; i++ {
+		entryBar := bars[i+1]
+		price := bars[i].Close
+		stop := price.Sub(price.Mul(cfg.StopDistancePct))
+		takeProfit := calc.TakeProfit(price, stop)
+
+		miniStop := calc.MiniStop(stop, fixedpoint.Zero, cfg.TickSize, cfg.Instrument, cfg.FXRate)
+		derivatePrice := calc.MiniLeverage(price, fixedpoint.Zero, cfg.Instrument, cfg.FXRate)
+		qty := calc.MiniQuantity(cfg.Risk, derivatePrice, miniStop, cfg.LotSize)
+		if qty.Sign() <= 0 {
+			continue
+		}
+
+		exit, win, exitIndex := simulateExit(bars, i+1, price, stop, takeProfit)
+		pnl := calc.MiniProfit(exit, fixedpoint.Zero, derivatePrice, qty, cfg.Instrument, cfg.FXRate).Sub(cfg.FeePerTrade)
+
+		balance = balance.Add(pnl)
+		totalFees = totalFees.Add(cfg.FeePerTrade)
+		if balance.Float64() > peak.Float64() {
+			peak = balance
+		}
+		if drawdown := peak.Sub(balance); drawdown.Float64() > maxDrawdown.Float64() {
+			maxDrawdown = drawdown
+		}
+
+		rMultiple := 0.0
+		if cfg.Risk.Float64() != 0 {
+			rMultiple = pnl.Float64() / cfg.Risk.Float64()
+		}
+		rSum += rMultiple
+		if cfg.InitialBalance.Float64() != 0 {
+			returns = append(returns, pnl.Float64()/cfg.InitialBalance.Float64())
+		}
+
+		trades = append(trades, Trade{
+			EntryTime:  entryBar,
+			EntryPrice: price,
+			StopPrice:  stop,
+			TakeProfit: takeProfit,
+			MiniQty:    qty,
+			ExitPrice:  exit,
+			PnL:        pnl,
+			RMultiple:  rMultiple,
+			Win:        win,
+		})
+
+		// Stay flat until this position's exit bar so trades never overlap.
+		i = exitIndex
+	}
+
+	report := &SessionSymbolReport{
+		Symbol:         symbol,
+		InitialBalance: cfg.InitialBalance,
+		FinalBalance:   balance,
+		TotalFees:      totalFees,
+		NumTrades:      len(trades),
+		MaxDrawdown:    maxDrawdown,
+		Trades:         trades,
+	}
+	if len(trades) > 0 {
+		wins := 0
+		for _, t := range trades {
+			if t.Win {
+				wins++
+			}
+		}
+		report.WinRate = float64(wins) / float64(len(trades))
+		report.AvgRMultiple = rSum / float64(len(trades))
+	}
+	report.Sharpe = sharpeRatio(returns)
+
+	return report, nil
+}
+
+// simulateExit walks bars[start:] forward, bar by bar, looking at each bar's
+// high/low to decide whether the stop or the take-profit is hit first. When
+// both are touched within the same bar the stop is assumed to have been hit
+// first, the conservative assumption. If neither level is touched by the end
+// of the series, the position is force-closed at the last bar's close and is
+// a win only if that close is above the entry price. It also returns the
+// index of the bar the exit happened on, so the caller can stay flat until
+// then.
+func simulateExit(bars []Bar, start int, entryPrice, stop, takeProfit fixedpoint.Value) (fixedpoint.Value, bool, int) {
+	last := bars[start]
+	lastIndex := start
+	for idx := start; idx < len(bars); idx++ {
+		bar := bars[idx]
+		last = bar
+		lastIndex = idx
+		if bar.Low.Float64() <= stop.Float64() {
+			return stop, false, lastIndex
+		}
+		if bar.High.Float64() >= takeProfit.Float64() {
+			return takeProfit, true, lastIndex
+		}
+	}
+	return last.Close, last.Close.Float64() > entryPrice.Float64(), lastIndex
+}
+
+func sharpeRatio(returns []float64) float64 {
+	if len(returns) < 2 {
+		return 0
+	}
+	var mean float64
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(len(returns))
+
+	var variance float64
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	variance /= float64(len(returns) - 1)
+	if variance == 0 {
+		return 0
+	}
+	return mean / math.Sqrt(variance)
+}
@@ -0,0 +1,182 @@
+package backtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0dayfall/minifuture/pkg/fixedpoint"
+	"github.com/0dayfall/minifuture/pkg/instrument"
+)
+
+func mustValue(t *testing.T, s string) fixedpoint.Value {
+	t.Helper()
+	v, err := fixedpoint.NewFromString(s)
+	if err != nil {
+		t.Fatalf("NewFromString(%q): %v", s, err)
+	}
+	return v
+}
+
+func TestRunHitsTakeProfit(t *testing.T) {
+	bars := []Bar{
+		{Time: time.Unix(0, 0), Open: mustValue(t, "100"), High: mustValue(t, "100"), Low: mustValue(t, "100"), Close: mustValue(t, "100")},
+		{Time: time.Unix(1, 0), Open: mustValue(t, "100"), High: mustValue(t, "110"), Low: mustValue(t, "100"), Close: mustValue(t, "104")},
+	}
+
+	cfg := Config{
+		Instrument:      &instrument.Instrument{ParityRatio: 8.34, QuoteCurrency: "SEK", FinanceCurrency: "SEK", Multiplier: 1},
+		FXRate:          1,
+		InitialBalance:  mustValue(t, "10000"),
+		Risk:            mustValue(t, "100"),
+		StopDistancePct: mustValue(t, "0.02"),
+		LotSize:         mustValue(t, "1"),
+		TickSize:        mustValue(t, "0.01"),
+	}
+
+	report, err := Run("TEST", bars, cfg)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if report.NumTrades != 1 {
+		t.Fatalf("NumTrades = %d, want 1", report.NumTrades)
+	}
+	if !report.Trades[0].Win {
+		t.Fatalf("expected the single trade to hit take-profit")
+	}
+}
+
+func TestRunHoldsAcrossMultipleBarsUntilStopOrTakeProfit(t *testing.T) {
+	bars := []Bar{
+		{Time: time.Unix(0, 0), Open: mustValue(t, "100"), High: mustValue(t, "100"), Low: mustValue(t, "100"), Close: mustValue(t, "100")},
+		{Time: time.Unix(1, 0), Open: mustValue(t, "100"), High: mustValue(t, "101"), Low: mustValue(t, "99"), Close: mustValue(t, "100")},
+		{Time: time.Unix(2, 0), Open: mustValue(t, "100"), High: mustValue(t, "101"), Low: mustValue(t, "99"), Close: mustValue(t, "100")},
+		{Time: time.Unix(3, 0), Open: mustValue(t, "100"), High: mustValue(t, "110"), Low: mustValue(t, "99"), Close: mustValue(t, "104")},
+	}
+
+	cfg := Config{
+		Instrument:      &instrument.Instrument{ParityRatio: 8.34, QuoteCurrency: "SEK", FinanceCurrency: "SEK", Multiplier: 1},
+		FXRate:          1,
+		InitialBalance:  mustValue(t, "10000"),
+		Risk:            mustValue(t, "100"),
+		StopDistancePct: mustValue(t, "0.02"),
+		LotSize:         mustValue(t, "1"),
+		TickSize:        mustValue(t, "0.01"),
+	}
+
+	report, err := Run("TEST", bars, cfg)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(report.Trades) == 0 {
+		t.Fatal("expected at least one trade")
+	}
+
+	// The first trade is entered after bar 0 and held through the flat bars
+	// 1-2, only exiting on bar 3's take-profit touch. If simulateExit only
+	// looked at the single next bar, it would have force-exited at bar 1's
+	// close instead.
+	first := report.Trades[0]
+	if !first.Win {
+		t.Fatalf("expected the held position to eventually hit take-profit on the last bar")
+	}
+	if first.ExitPrice.Float64() != first.TakeProfit.Float64() {
+		t.Fatalf("ExitPrice = %v, want take-profit %v", first.ExitPrice.Float64(), first.TakeProfit.Float64())
+	}
+}
+
+func TestRunRMultipleIsPnLOverRiskBudget(t *testing.T) {
+	bars := []Bar{
+		{Time: time.Unix(0, 0), Open: mustValue(t, "100"), High: mustValue(t, "100"), Low: mustValue(t, "100"), Close: mustValue(t, "100")},
+		{Time: time.Unix(1, 0), Open: mustValue(t, "100"), High: mustValue(t, "110"), Low: mustValue(t, "100"), Close: mustValue(t, "104")},
+	}
+
+	cfg := Config{
+		Instrument:      &instrument.Instrument{ParityRatio: 8.34, QuoteCurrency: "SEK", FinanceCurrency: "SEK", Multiplier: 1},
+		FXRate:          1,
+		InitialBalance:  mustValue(t, "10000"),
+		Risk:            mustValue(t, "100"),
+		StopDistancePct: mustValue(t, "0.02"),
+		LotSize:         mustValue(t, "1"),
+		TickSize:        mustValue(t, "0.01"),
+	}
+
+	report, err := Run("TEST", bars, cfg)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(report.Trades) != 1 {
+		t.Fatalf("NumTrades = %d, want 1", len(report.Trades))
+	}
+
+	trade := report.Trades[0]
+	want := trade.PnL.Float64() / cfg.Risk.Float64()
+	if trade.RMultiple != want {
+		t.Fatalf("RMultiple = %v, want PnL/Risk = %v", trade.RMultiple, want)
+	}
+}
+
+func TestRunDoesNotOpenOverlappingTrades(t *testing.T) {
+	// Every bar after the first touches neither stop nor take-profit, so a
+	// version that opened a new position on every bar close would report one
+	// trade per bar. Flat-only entry should instead hold the first trade
+	// through to the final bar's force-close and report just that one trade.
+	bars := make([]Bar, 10)
+	for i := range bars {
+		bars[i] = Bar{
+			Time:  time.Unix(int64(i), 0),
+			Open:  mustValue(t, "100"),
+			High:  mustValue(t, "100.5"),
+			Low:   mustValue(t, "99.5"),
+			Close: mustValue(t, "100"),
+		}
+	}
+
+	cfg := Config{
+		Instrument:      &instrument.Instrument{ParityRatio: 8.34, QuoteCurrency: "SEK", FinanceCurrency: "SEK", Multiplier: 1},
+		FXRate:          1,
+		InitialBalance:  mustValue(t, "10000"),
+		Risk:            mustValue(t, "100"),
+		StopDistancePct: mustValue(t, "0.02"),
+		LotSize:         mustValue(t, "1"),
+		TickSize:        mustValue(t, "0.01"),
+	}
+
+	report, err := Run("TEST", bars, cfg)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if report.NumTrades != 1 {
+		t.Fatalf("NumTrades = %d, want 1 (trades must not overlap)", report.NumTrades)
+	}
+}
+
+func TestRunForceCloseLossIsNotCountedAsWin(t *testing.T) {
+	// Entry at 100, stop at 98; the only other bar's close sits between the
+	// stop and the entry price, so the force-closed trade is a loss even
+	// though its close is above the stop.
+	bars := []Bar{
+		{Time: time.Unix(0, 0), Open: mustValue(t, "100"), High: mustValue(t, "100"), Low: mustValue(t, "100"), Close: mustValue(t, "100")},
+		{Time: time.Unix(1, 0), Open: mustValue(t, "100"), High: mustValue(t, "100.5"), Low: mustValue(t, "98.5"), Close: mustValue(t, "99")},
+	}
+
+	cfg := Config{
+		Instrument:      &instrument.Instrument{ParityRatio: 8.34, QuoteCurrency: "SEK", FinanceCurrency: "SEK", Multiplier: 1},
+		FXRate:          1,
+		InitialBalance:  mustValue(t, "10000"),
+		Risk:            mustValue(t, "100"),
+		StopDistancePct: mustValue(t, "0.02"),
+		LotSize:         mustValue(t, "1"),
+		TickSize:        mustValue(t, "0.01"),
+	}
+
+	report, err := Run("TEST", bars, cfg)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(report.Trades) != 1 {
+		t.Fatalf("NumTrades = %d, want 1", len(report.Trades))
+	}
+	if report.Trades[0].Win {
+		t.Fatalf("expected a force-closed trade below the entry price to be a loss")
+	}
+}
@@ -0,0 +1,81 @@
+package backtest
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/0dayfall/minifuture/pkg/fixedpoint"
+)
+
+// Bar is one OHLC bar for the underlying stock.
+type Bar struct {
+	Time  time.Time
+	Open  fixedpoint.Value
+	High  fixedpoint.Value
+	Low   fixedpoint.Value
+	Close fixedpoint.Value
+}
+
+// LoadBarsCSV reads bars from a CSV file with the header
+// "time,open,high,low,close", time formatted as RFC3339 or "2006-01-02".
+func LoadBarsCSV(path string) ([]Bar, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("backtest: opening %s: %w", path, err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("backtest: reading %s: %w", path, err)
+	}
+	if len(rows) < 2 {
+		return nil, fmt.Errorf("backtest: %s has no data rows", path)
+	}
+
+	bars := make([]Bar, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		if len(row) < 5 {
+			return nil, fmt.Errorf("backtest: %s row %d: expected 5 columns, got %d", path, i+2, len(row))
+		}
+
+		t, err := parseBarTime(row[0])
+		if err != nil {
+			return nil, fmt.Errorf("backtest: %s row %d: %w", path, i+2, err)
+		}
+
+		open, err := fixedpoint.NewFromString(row[1])
+		if err != nil {
+			return nil, fmt.Errorf("backtest: %s row %d: open: %w", path, i+2, err)
+		}
+		high, err := fixedpoint.NewFromString(row[2])
+		if err != nil {
+			return nil, fmt.Errorf("backtest: %s row %d: high: %w", path, i+2, err)
+		}
+		low, err := fixedpoint.NewFromString(row[3])
+		if err != nil {
+			return nil, fmt.Errorf("backtest: %s row %d: low: %w", path, i+2, err)
+		}
+		closePrice, err := fixedpoint.NewFromString(row[4])
+		if err != nil {
+			return nil, fmt.Errorf("backtest: %s row %d: close: %w", path, i+2, err)
+		}
+
+		bars = append(bars, Bar{Time: t, Open: open, High: high, Low: low, Close: closePrice})
+	}
+
+	return bars, nil
+}
+
+func parseBarTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time %q, want RFC3339 or 2006-01-02", s)
+}
@@ -0,0 +1,54 @@
+// Package calc implements the mini-future position sizing formulas using
+// fixedpoint.Value arithmetic, so that prices parsed from strings (rather
+// than float64 flags) never drift during the subtractions and divisions
+// that feed into share and leverage counts.
+package calc
+
+import (
+	"github.com/0dayfall/minifuture/pkg/fixedpoint"
+	"github.com/0dayfall/minifuture/pkg/instrument"
+)
+
+// NumberOfStocks returns how many whole shares of the underlying can be
+// bought for the given risk budget, rounded down to lotSize.
+func NumberOfStocks(risk, price, stop, lotSize fixedpoint.Value) fixedpoint.Value {
+	raw := risk.Div(price.Sub(stop))
+	return fixedpoint.RoundDownLot(raw, lotSize)
+}
+
+// TakeProfit returns the stock take-profit price for a 2R target.
+func TakeProfit(price, stop fixedpoint.Value) fixedpoint.Value {
+	return price.Add(price.Sub(stop).MulFloat(2))
+}
+
+// MiniLeverage returns the mini-future's leverage against the financing
+// level, i.e. how many mini units one unit of the underlying corresponds to.
+// price and financeLevel are in inst.FinanceCurrency; fxRate converts that
+// delta into inst.QuoteCurrency before applying the instrument's parity and
+// contract multiplier.
+func MiniLeverage(price, financeLevel fixedpoint.Value, inst *instrument.Instrument, fxRate float64) fixedpoint.Value {
+	deltaQuote := price.Sub(financeLevel).MulFloat(fxRate).MulFloat(inst.Multiplier)
+	return deltaQuote.Div(fixedpoint.NewFromFloat(100)).MulFloat(inst.ParityRatio)
+}
+
+// MiniStop returns the derivative price implied by the stock's stop level.
+func MiniStop(stop, financeLevel, tickSize fixedpoint.Value, inst *instrument.Instrument, fxRate float64) fixedpoint.Value {
+	deltaQuote := stop.Sub(financeLevel).MulFloat(fxRate).MulFloat(inst.Multiplier)
+	raw := deltaQuote.Div(fixedpoint.NewFromFloat(100)).MulFloat(inst.ParityRatio)
+	return fixedpoint.RoundToTick(raw, tickSize)
+}
+
+// MiniQuantity returns how many mini-future units can be bought for the
+// given risk budget, rounded down to lotSize.
+func MiniQuantity(risk, derivatePrice, miniStop, lotSize fixedpoint.Value) fixedpoint.Value {
+	raw := risk.Div(derivatePrice.Sub(miniStop))
+	return fixedpoint.RoundDownLot(raw, lotSize)
+}
+
+// MiniProfit returns the projected profit in the mini-future position at
+// the stock's take-profit level.
+func MiniProfit(takeProfit, financeLevel, derivatePrice, quantity fixedpoint.Value, inst *instrument.Instrument, fxRate float64) fixedpoint.Value {
+	deltaQuote := takeProfit.Sub(financeLevel).MulFloat(fxRate).MulFloat(inst.Multiplier)
+	miniAtTakeProfit := deltaQuote.Div(fixedpoint.NewFromFloat(100)).MulFloat(inst.ParityRatio)
+	return miniAtTakeProfit.Mul(quantity).Sub(derivatePrice.Mul(quantity))
+}
@@ -0,0 +1,54 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/0dayfall/minifuture/pkg/fixedpoint"
+	"github.com/0dayfall/minifuture/pkg/instrument"
+)
+
+func mustValue(t *testing.T, s string) fixedpoint.Value {
+	t.Helper()
+	v, err := fixedpoint.NewFromString(s)
+	if err != nil {
+		t.Fatalf("NewFromString(%q): %v", s, err)
+	}
+	return v
+}
+
+func TestNumberOfStocksRoundsDownToLot(t *testing.T) {
+	risk := mustValue(t, "1000")
+	price := mustValue(t, "105")
+	stop := mustValue(t, "100")
+	lot := mustValue(t, "1")
+
+	got := NumberOfStocks(risk, price, stop, lot)
+	if got.Float64() != 200 {
+		t.Fatalf("NumberOfStocks() = %v, want 200", got.Float64())
+	}
+}
+
+func TestTakeProfitIsTwoRAboveEntry(t *testing.T) {
+	price := mustValue(t, "105")
+	stop := mustValue(t, "100")
+
+	got := TakeProfit(price, stop)
+	if got.Float64() != 115 {
+		t.Fatalf("TakeProfit() = %v, want 115", got.Float64())
+	}
+}
+
+func TestMiniLeverageScalesWithContractMultiplier(t *testing.T) {
+	price := mustValue(t, "110")
+	financeLevel := mustValue(t, "100")
+
+	single := &instrument.Instrument{ParityRatio: 1, QuoteCurrency: "SEK", FinanceCurrency: "SEK", Multiplier: 1}
+	double := &instrument.Instrument{ParityRatio: 1, QuoteCurrency: "SEK", FinanceCurrency: "SEK", Multiplier: 2}
+
+	gotSingle := MiniLeverage(price, financeLevel, single, 1)
+	gotDouble := MiniLeverage(price, financeLevel, double, 1)
+
+	if gotDouble.Float64() != gotSingle.Float64()*2 {
+		t.Fatalf("MiniLeverage() with Multiplier=2 = %v, want double Multiplier=1 value %v", gotDouble.Float64(), gotSingle.Float64())
+	}
+}
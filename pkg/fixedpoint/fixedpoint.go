@@ -0,0 +1,172 @@
+// Package fixedpoint implements a decimal value with a fixed number of
+// fractional digits, so that money math does not drift the way raw float64
+// arithmetic does. It mirrors the approach used by bbgo's fixedpoint and
+// shopspring/decimal: values are stored as a scaled integer and only ever
+// converted to float64 at the edges (printing, flag parsing).
+package fixedpoint
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Precision is the number of fractional digits every Value is scaled by.
+const Precision = 8
+
+var scale = int64(math.Pow10(Precision))
+
+// Value is a fixed-point decimal stored as an integer number of
+// 10^-Precision units.
+type Value int64
+
+// Zero is the additive identity.
+var Zero = Value(0)
+
+// NewFromString parses a decimal string such as "123.4567" without going
+// through float64, so prices like financing levels keep their exact digits.
+func NewFromString(s string) (Value, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Zero, fmt.Errorf("fixedpoint: empty string")
+	}
+
+	negative := false
+	if strings.HasPrefix(s, "-") {
+		negative = true
+		s = s[1:]
+	}
+
+	parts := strings.SplitN(s, ".", 2)
+	intPart := parts[0]
+	fracPart := ""
+	if len(parts) == 2 {
+		fracPart = parts[1]
+	}
+	if len(fracPart) > Precision {
+		fracPart = fracPart[:Precision]
+	}
+	for len(fracPart) < Precision {
+		fracPart += "0"
+	}
+
+	if intPart == "" {
+		intPart = "0"
+	}
+
+	digits := intPart + fracPart
+	n, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return Zero, fmt.Errorf("fixedpoint: parsing %q: %w", s, err)
+	}
+	if negative {
+		n = -n
+	}
+	return Value(n), nil
+}
+
+// NewFromFloat converts a float64 into a Value. Prefer NewFromString when the
+// original input is a string, to avoid inheriting float64 rounding error.
+func NewFromFloat(f float64) Value {
+	return Value(math.Round(f * float64(scale)))
+}
+
+// Float64 returns the value as a float64, for printing and for APIs (such as
+// an HTTP quote provider) that only speak float64.
+func (v Value) Float64() float64 {
+	return float64(v) / float64(scale)
+}
+
+// String renders the value with Precision fractional digits trimmed of
+// trailing zeros, matching how the CLI historically printed plain floats.
+func (v Value) String() string {
+	return strconv.FormatFloat(v.Float64(), 'f', -1, 64)
+}
+
+// Add returns v + other.
+func (v Value) Add(other Value) Value {
+	return v + other
+}
+
+// Sub returns v - other.
+func (v Value) Sub(other Value) Value {
+	return v - other
+}
+
+// Mul returns v * other, rescaling back down to Precision digits. The
+// multiplication and rescale happen in arbitrary-precision integer space so
+// that scaled products exceeding 2^53 (ordinary prices at Precision=8) don't
+// lose bits the way a float64 round-trip would.
+func (v Value) Mul(other Value) Value {
+	num := new(big.Int).Mul(big.NewInt(int64(v)), big.NewInt(int64(other)))
+	return Value(divRound(num, big.NewInt(scale)))
+}
+
+// Div returns v / other, rescaling up to Precision digits first, entirely in
+// integer space for the same reason as Mul.
+func (v Value) Div(other Value) Value {
+	num := new(big.Int).Mul(big.NewInt(int64(v)), big.NewInt(scale))
+	return Value(divRound(num, big.NewInt(int64(other))))
+}
+
+// divRound divides num by den and rounds to the nearest integer, half away
+// from zero, matching MulFloat's rounding so Mul/Div/MulFloat agree. A zero
+// den returns 0 rather than panicking, mirroring how the float64 baseline
+// produced Inf/NaN (not representable as a Value) instead of crashing.
+func divRound(num, den *big.Int) int64 {
+	if den.Sign() == 0 {
+		return 0
+	}
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() != 0 {
+		// 2*|rem| >= |den| means the remainder is at least half a unit.
+		twice := new(big.Int).Lsh(new(big.Int).Abs(rem), 1)
+		if twice.Cmp(new(big.Int).Abs(den)) >= 0 {
+			if (num.Sign() < 0) != (den.Sign() < 0) {
+				quo.Sub(quo, big.NewInt(1))
+			} else {
+				quo.Add(quo, big.NewInt(1))
+			}
+		}
+	}
+	return quo.Int64()
+}
+
+// MulFloat multiplies v by a plain ratio, such as a parity factor.
+func (v Value) MulFloat(ratio float64) Value {
+	return Value(math.Round(float64(v) * ratio))
+}
+
+// Sign returns -1, 0 or 1.
+func (v Value) Sign() int {
+	switch {
+	case v < 0:
+		return -1
+	case v > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// RoundDownLot rounds v toward zero to a whole multiple of lotSize, e.g.
+// rounding a share count down to whole shares when lotSize is 1.
+func RoundDownLot(v Value, lotSize Value) Value {
+	if lotSize <= 0 {
+		return v
+	}
+	units := int64(v) / int64(lotSize)
+	return Value(units * int64(lotSize))
+}
+
+// RoundToTick rounds v to the nearest multiple of tickSize, e.g. rounding a
+// derivative price to its exchange's minimum price increment.
+func RoundToTick(v Value, tickSize Value) Value {
+	if tickSize <= 0 {
+		return v
+	}
+	units := math.Round(float64(v) / float64(tickSize))
+	return Value(units * float64(int64(tickSize)))
+}
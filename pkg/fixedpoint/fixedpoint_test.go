@@ -0,0 +1,106 @@
+package fixedpoint
+
+import "testing"
+
+func mustValue(t *testing.T, s string) Value {
+	t.Helper()
+	v, err := NewFromString(s)
+	if err != nil {
+		t.Fatalf("NewFromString(%q): %v", s, err)
+	}
+	return v
+}
+
+func TestNewFromStringParsesWholeAndFractional(t *testing.T) {
+	got := mustValue(t, "123.4567")
+	if got.Float64() != 123.4567 {
+		t.Fatalf("Float64() = %v, want 123.4567", got.Float64())
+	}
+}
+
+func TestNewFromStringHandlesNegativeAndTruncatesExtraDigits(t *testing.T) {
+	got := mustValue(t, "-1.123456789")
+	want := mustValue(t, "-1.12345678")
+	if got != want {
+		t.Fatalf("NewFromString(-1.123456789) = %v, want %v", got, want)
+	}
+}
+
+func TestNewFromStringRejectsEmpty(t *testing.T) {
+	if _, err := NewFromString(""); err == nil {
+		t.Fatal("NewFromString(\"\") error = nil, want error")
+	}
+}
+
+func TestNewFromStringRejectsGarbage(t *testing.T) {
+	if _, err := NewFromString("not-a-number"); err == nil {
+		t.Fatal("NewFromString(garbage) error = nil, want error")
+	}
+}
+
+func TestMulRoundsHalfAwayFromZero(t *testing.T) {
+	a := mustValue(t, "1.00000005")
+	b := mustValue(t, "0.5")
+	got := a.Mul(b)
+	// 1.00000005 * 0.5 = 0.500000025, exactly half a unit past 0.50000002.
+	want := mustValue(t, "0.50000003")
+	if got != want {
+		t.Fatalf("Mul() = %v, want %v", got, want)
+	}
+}
+
+func TestMulMatchesPlainMultiplication(t *testing.T) {
+	a := mustValue(t, "110")
+	b := mustValue(t, "0.5")
+	got := a.Mul(b)
+	want := mustValue(t, "55")
+	if got != want {
+		t.Fatalf("Mul() = %v, want %v", got, want)
+	}
+}
+
+func TestDivMatchesPlainDivision(t *testing.T) {
+	a := mustValue(t, "100")
+	b := mustValue(t, "4")
+	got := a.Div(b)
+	want := mustValue(t, "25")
+	if got != want {
+		t.Fatalf("Div() = %v, want %v", got, want)
+	}
+}
+
+func TestDivByZeroReturnsZeroInsteadOfPanicking(t *testing.T) {
+	a := mustValue(t, "100")
+	got := a.Div(Zero)
+	if got != Zero {
+		t.Fatalf("Div(0) = %v, want Zero", got)
+	}
+}
+
+func TestRoundDownLotRoundsTowardZero(t *testing.T) {
+	v := mustValue(t, "205")
+	lot := mustValue(t, "100")
+	got := RoundDownLot(v, lot)
+	want := mustValue(t, "200")
+	if got != want {
+		t.Fatalf("RoundDownLot() = %v, want %v", got, want)
+	}
+}
+
+func TestRoundDownLotIgnoresNonPositiveLotSize(t *testing.T) {
+	v := mustValue(t, "205")
+	got := RoundDownLot(v, Zero)
+	if got != v {
+		t.Fatalf("RoundDownLot() with zero lotSize = %v, want %v unchanged", got, v)
+	}
+}
+
+func TestRoundToTickRoundsToNearestTick(t *testing.T) {
+	v := mustValue(t, "100.037")
+	tick := mustValue(t, "0.05")
+	got := RoundToTick(v, tick)
+	want := mustValue(t, "100.05")
+	if got != want {
+		t.Fatalf("RoundToTick() = %v, want %v", got, want)
+	}
+}
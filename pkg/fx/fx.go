@@ -0,0 +1,39 @@
+// Package fx converts amounts between the currencies an Instrument's
+// underlying and derivative may be quoted in.
+package fx
+
+import "fmt"
+
+// RateProvider returns the rate to multiply an amount in "from" by to get
+// an amount in "to".
+type RateProvider interface {
+	Rate(from, to string) (float64, error)
+}
+
+// StaticRateProvider is a RateProvider backed by a fixed lookup table, for
+// tests and for currency pairs an operator wants to pin manually.
+type StaticRateProvider struct {
+	rates map[string]float64
+}
+
+// NewStaticRateProvider builds a StaticRateProvider from a "FROM/TO" keyed
+// rate table, e.g. {"USD/SEK": 10.5}.
+func NewStaticRateProvider(rates map[string]float64) *StaticRateProvider {
+	return &StaticRateProvider{rates: rates}
+}
+
+// Rate implements RateProvider.
+func (s *StaticRateProvider) Rate(from, to string) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+	key := from + "/" + to
+	if rate, ok := s.rates[key]; ok {
+		return rate, nil
+	}
+	inverseKey := to + "/" + from
+	if rate, ok := s.rates[inverseKey]; ok && rate != 0 {
+		return 1 / rate, nil
+	}
+	return 0, fmt.Errorf("fx: no rate configured for %s", key)
+}
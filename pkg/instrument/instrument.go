@@ -0,0 +1,61 @@
+// Package instrument describes the per-instrument configuration the mini-
+// future formulas need beyond raw prices: the parity ratio between the
+// underlying and the derivative, the currencies each side is quoted in, and
+// the derivative's contract multiplier.
+package instrument
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Instrument holds the static configuration for one mini-future contract.
+// It is loaded from a JSON file rather than hard-coded, since the same
+// formulas are reused across issuers and underlyings with different parity
+// ratios and currencies.
+type Instrument struct {
+	// Symbol is the derivative's ticker, e.g. "MINILONG123".
+	Symbol string `json:"symbol"`
+	// Underlying is the underlying stock's ticker, e.g. "AAPL".
+	Underlying string `json:"underlying"`
+	// ParityRatio is how many mini-future units correspond to one unit of
+	// the underlying's price move, replacing the old hard-coded 8.34.
+	ParityRatio float64 `json:"parityRatio"`
+	// QuoteCurrency is the currency the derivative itself trades in.
+	QuoteCurrency string `json:"quoteCurrency"`
+	// FinanceCurrency is the currency the underlying and its financing
+	// level are denominated in.
+	FinanceCurrency string `json:"financeCurrency"`
+	// Multiplier is the derivative's contract multiplier, i.e. how many
+	// units of the underlying one derivative unit represents.
+	Multiplier float64 `json:"multiplier"`
+}
+
+// Load reads and validates an Instrument definition from a JSON file.
+func Load(path string) (*Instrument, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("instrument: reading %s: %w", path, err)
+	}
+
+	var inst Instrument
+	if err := json.Unmarshal(data, &inst); err != nil {
+		return nil, fmt.Errorf("instrument: parsing %s: %w", path, err)
+	}
+
+	if inst.ParityRatio <= 0 {
+		return nil, fmt.Errorf("instrument: %s has no positive parityRatio", path)
+	}
+	if inst.QuoteCurrency == "" {
+		return nil, fmt.Errorf("instrument: %s has no quoteCurrency", path)
+	}
+	if inst.FinanceCurrency == "" {
+		return nil, fmt.Errorf("instrument: %s has no financeCurrency", path)
+	}
+	if inst.Multiplier == 0 {
+		inst.Multiplier = 1
+	}
+
+	return &inst, nil
+}
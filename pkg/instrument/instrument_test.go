@@ -0,0 +1,40 @@
+package instrument
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadValidatesRequiredFields(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "instrument.json")
+	body := `{"symbol":"MINILONG123","underlying":"AAPL","parityRatio":8.34,"quoteCurrency":"SEK","financeCurrency":"USD"}`
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	inst, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if inst.ParityRatio != 8.34 {
+		t.Errorf("ParityRatio = %v, want 8.34", inst.ParityRatio)
+	}
+	if inst.Multiplier != 1 {
+		t.Errorf("Multiplier = %v, want default of 1", inst.Multiplier)
+	}
+}
+
+func TestLoadRejectsMissingParityRatio(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "instrument.json")
+	body := `{"symbol":"MINILONG123","quoteCurrency":"SEK","financeCurrency":"SEK"}`
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if _, err := Load(path); err == nil {
+		t.Fatal("Load() error = nil, want error for missing parityRatio")
+	}
+}
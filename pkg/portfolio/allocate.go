@@ -0,0 +1,92 @@
+package portfolio
+
+import (
+	"fmt"
+
+	"github.com/0dayfall/minifuture/pkg/fixedpoint"
+)
+
+// AllocationStrategy splits a total risk budget across legs, returning the
+// risk assigned to each leg's symbol.
+type AllocationStrategy interface {
+	Allocate(legs []Leg, totalRisk fixedpoint.Value) (map[string]fixedpoint.Value, error)
+}
+
+// EqualRiskStrategy splits the budget evenly across every leg.
+type EqualRiskStrategy struct{}
+
+// Allocate implements AllocationStrategy.
+func (EqualRiskStrategy) Allocate(legs []Leg, totalRisk fixedpoint.Value) (map[string]fixedpoint.Value, error) {
+	if len(legs) == 0 {
+		return nil, fmt.Errorf("portfolio: no legs to allocate across")
+	}
+	share := totalRisk.Div(fixedpoint.NewFromFloat(float64(len(legs))))
+	out := make(map[string]fixedpoint.Value, len(legs))
+	for _, leg := range legs {
+		out[leg.Symbol] = share
+	}
+	return out, nil
+}
+
+// WeightedStrategy splits the budget proportionally to each leg's Weight.
+type WeightedStrategy struct{}
+
+// Allocate implements AllocationStrategy.
+func (WeightedStrategy) Allocate(legs []Leg, totalRisk fixedpoint.Value) (map[string]fixedpoint.Value, error) {
+	var totalWeight float64
+	for _, leg := range legs {
+		totalWeight += leg.Weight
+	}
+	if totalWeight <= 0 {
+		return nil, fmt.Errorf("portfolio: weighted allocation requires positive leg weights")
+	}
+	out := make(map[string]fixedpoint.Value, len(legs))
+	for _, leg := range legs {
+		out[leg.Symbol] = totalRisk.MulFloat(leg.Weight / totalWeight)
+	}
+	return out, nil
+}
+
+// KellyFractionStrategy splits the budget proportionally to each leg's
+// Kelly fraction, f = winProbability - (1-winProbability)/rewardRiskRatio,
+// clamped to zero so legs with a negative edge get no allocation.
+type KellyFractionStrategy struct{}
+
+// Allocate implements AllocationStrategy.
+func (KellyFractionStrategy) Allocate(legs []Leg, totalRisk fixedpoint.Value) (map[string]fixedpoint.Value, error) {
+	fractions := make(map[string]float64, len(legs))
+	var total float64
+	for _, leg := range legs {
+		if leg.RewardRiskRatio <= 0 {
+			return nil, fmt.Errorf("portfolio: leg %s needs a positive rewardRiskRatio for Kelly allocation", leg.Symbol)
+		}
+		f := leg.WinProbability - (1-leg.WinProbability)/leg.RewardRiskRatio
+		if f < 0 {
+			f = 0
+		}
+		fractions[leg.Symbol] = f
+		total += f
+	}
+	if total <= 0 {
+		return nil, fmt.Errorf("portfolio: no leg has a positive Kelly fraction")
+	}
+	out := make(map[string]fixedpoint.Value, len(legs))
+	for _, leg := range legs {
+		out[leg.Symbol] = totalRisk.MulFloat(fractions[leg.Symbol] / total)
+	}
+	return out, nil
+}
+
+// StrategyByName resolves a strategy flag value to an AllocationStrategy.
+func StrategyByName(name string) (AllocationStrategy, error) {
+	switch name {
+	case "equal-risk", "":
+		return EqualRiskStrategy{}, nil
+	case "kelly-fraction":
+		return KellyFractionStrategy{}, nil
+	case "weighted":
+		return WeightedStrategy{}, nil
+	default:
+		return nil, fmt.Errorf("portfolio: unknown allocation strategy %q", name)
+	}
+}
@@ -0,0 +1,29 @@
+package portfolio
+
+import (
+	"testing"
+
+	"github.com/0dayfall/minifuture/pkg/fixedpoint"
+)
+
+func TestEqualRiskStrategySplitsEvenly(t *testing.T) {
+	legs := []Leg{{Symbol: "A"}, {Symbol: "B"}}
+	totalRisk, _ := fixedpoint.NewFromString("1000")
+
+	allocation, err := EqualRiskStrategy{}.Allocate(legs, totalRisk)
+	if err != nil {
+		t.Fatalf("Allocate() error = %v", err)
+	}
+	if allocation["A"].Float64() != 500 || allocation["B"].Float64() != 500 {
+		t.Fatalf("Allocate() = %v, want 500/500", allocation)
+	}
+}
+
+func TestWeightedStrategyRequiresPositiveWeights(t *testing.T) {
+	legs := []Leg{{Symbol: "A", Weight: 0}, {Symbol: "B", Weight: 0}}
+	totalRisk, _ := fixedpoint.NewFromString("1000")
+
+	if _, err := (WeightedStrategy{}).Allocate(legs, totalRisk); err == nil {
+		t.Fatal("Allocate() error = nil, want error for zero total weight")
+	}
+}
@@ -0,0 +1,64 @@
+// Package portfolio generalizes the single-instrument sizing calculator
+// into a position-planning tool: it allocates a total risk budget across
+// several candidate mini-future trades and reports the sizing for each.
+package portfolio
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Leg is one candidate trade in a portfolio config file.
+type Leg struct {
+	Symbol          string  `yaml:"symbol"`
+	Price           string  `yaml:"price"`
+	Stop            string  `yaml:"stop"`
+	FinanceLevel    string  `yaml:"financeLevel"`
+	DerivatePrice   string  `yaml:"derivatePrice"`
+	LotSize         string  `yaml:"lotSize"`
+	TickSize        string  `yaml:"tickSize"`
+	ParityRatio     float64 `yaml:"parityRatio"`
+	MaxLeverage     float64 `yaml:"maxLeverage"`
+	Weight          float64 `yaml:"weight"`          // used by the weighted strategy
+	WinProbability  float64 `yaml:"winProbability"`  // used by the Kelly-fraction strategy
+	RewardRiskRatio float64 `yaml:"rewardRiskRatio"` // used by the Kelly-fraction strategy
+}
+
+// Config is a full portfolio config file: a total risk budget and the legs
+// to consider allocating it across.
+type Config struct {
+	RiskBudget string `yaml:"riskBudget"`
+	Legs       []Leg  `yaml:"legs"`
+}
+
+// LoadConfig reads and validates a portfolio Config from a YAML file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("portfolio: reading %s: %w", path, err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("portfolio: parsing %s: %w", path, err)
+	}
+
+	if cfg.RiskBudget == "" {
+		return nil, fmt.Errorf("portfolio: %s has no riskBudget", path)
+	}
+	if len(cfg.Legs) == 0 {
+		return nil, fmt.Errorf("portfolio: %s has no legs", path)
+	}
+	for i, leg := range cfg.Legs {
+		if leg.Symbol == "" {
+			return nil, fmt.Errorf("portfolio: %s leg %d has no symbol", path, i)
+		}
+		if leg.ParityRatio <= 0 {
+			return nil, fmt.Errorf("portfolio: %s leg %s has no positive parityRatio", path, leg.Symbol)
+		}
+	}
+
+	return &cfg, nil
+}
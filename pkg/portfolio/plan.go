@@ -0,0 +1,116 @@
+package portfolio
+
+import (
+	"fmt"
+
+	"github.com/0dayfall/minifuture/pkg/calc"
+	"github.com/0dayfall/minifuture/pkg/fixedpoint"
+	"github.com/0dayfall/minifuture/pkg/instrument"
+)
+
+// LegResult is one leg's sizing outcome under a Plan.
+type LegResult struct {
+	Symbol         string
+	AllocatedRisk  fixedpoint.Value
+	NumberOfStocks fixedpoint.Value
+	MiniQuantity   fixedpoint.Value
+	Leverage       fixedpoint.Value
+	MiniProfit     fixedpoint.Value
+	Rejected       bool
+	RejectReason   string
+}
+
+// Plan is the outcome of allocating a risk budget across a set of legs.
+type Plan struct {
+	TotalRisk       fixedpoint.Value
+	Legs            []LegResult
+	AggregateProfit fixedpoint.Value
+}
+
+// Build allocates totalRisk across legs using strategy, sizes each leg with
+// pkg/calc, and rejects legs that breach their own max-leverage cap or whose
+// price fields cannot be parsed. An allocation error fails the whole Build.
+func Build(legs []Leg, totalRisk fixedpoint.Value, strategy AllocationStrategy) (*Plan, error) {
+	allocation, err := strategy.Allocate(legs, totalRisk)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{TotalRisk: totalRisk}
+
+	for _, leg := range legs {
+		result, err := sizeLeg(leg, allocation[leg.Symbol])
+		if err != nil {
+			plan.Legs = append(plan.Legs, LegResult{
+				Symbol:        leg.Symbol,
+				AllocatedRisk: allocation[leg.Symbol],
+				Rejected:      true,
+				RejectReason:  err.Error(),
+			})
+			continue
+		}
+
+		if leg.MaxLeverage > 0 && result.Leverage.Float64() > leg.MaxLeverage {
+			result.Rejected = true
+			result.RejectReason = fmt.Sprintf("leverage %.2f exceeds maxLeverage %.2f", result.Leverage.Float64(), leg.MaxLeverage)
+		}
+
+		plan.Legs = append(plan.Legs, *result)
+		if !result.Rejected {
+			plan.AggregateProfit = plan.AggregateProfit.Add(result.MiniProfit)
+		}
+	}
+
+	return plan, nil
+}
+
+func sizeLeg(leg Leg, allocatedRisk fixedpoint.Value) (*LegResult, error) {
+	price, err := fixedpoint.NewFromString(leg.Price)
+	if err != nil {
+		return nil, fmt.Errorf("price: %w", err)
+	}
+	stop, err := fixedpoint.NewFromString(leg.Stop)
+	if err != nil {
+		return nil, fmt.Errorf("stop: %w", err)
+	}
+	financeLevel, err := fixedpoint.NewFromString(leg.FinanceLevel)
+	if err != nil {
+		return nil, fmt.Errorf("financeLevel: %w", err)
+	}
+	derivatePrice, err := fixedpoint.NewFromString(leg.DerivatePrice)
+	if err != nil {
+		return nil, fmt.Errorf("derivatePrice: %w", err)
+	}
+	lotSize := fixedpoint.NewFromFloat(1)
+	if leg.LotSize != "" {
+		lotSize, err = fixedpoint.NewFromString(leg.LotSize)
+		if err != nil {
+			return nil, fmt.Errorf("lotSize: %w", err)
+		}
+	}
+	tickSize := fixedpoint.NewFromFloat(0.01)
+	if leg.TickSize != "" {
+		tickSize, err = fixedpoint.NewFromString(leg.TickSize)
+		if err != nil {
+			return nil, fmt.Errorf("tickSize: %w", err)
+		}
+	}
+
+	inst := &instrument.Instrument{ParityRatio: leg.ParityRatio, QuoteCurrency: "SEK", FinanceCurrency: "SEK", Multiplier: 1}
+
+	numberOfStocks := calc.NumberOfStocks(allocatedRisk, price, stop, lotSize)
+	takeProfit := calc.TakeProfit(price, stop)
+	leverage := calc.MiniLeverage(price, financeLevel, inst, 1)
+	miniStop := calc.MiniStop(stop, financeLevel, tickSize, inst, 1)
+	miniQty := calc.MiniQuantity(allocatedRisk, derivatePrice, miniStop, lotSize)
+	miniProfit := calc.MiniProfit(takeProfit, financeLevel, derivatePrice, miniQty, inst, 1)
+
+	return &LegResult{
+		Symbol:         leg.Symbol,
+		AllocatedRisk:  allocatedRisk,
+		NumberOfStocks: numberOfStocks,
+		MiniQuantity:   miniQty,
+		Leverage:       leverage,
+		MiniProfit:     miniProfit,
+	}, nil
+}
@@ -0,0 +1,26 @@
+package portfolio
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// WriteReport prints a consolidated table of the plan's legs plus the
+// aggregate expected profit, to w.
+func WriteReport(w io.Writer, plan *Plan) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "SYMBOL\tRISK\tSHARES\tMINI QTY\tLEVERAGE\tMINI PROFIT\tSTATUS")
+	for _, leg := range plan.Legs {
+		status := "ok"
+		if leg.Rejected {
+			status = "rejected: " + leg.RejectReason
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			leg.Symbol, leg.AllocatedRisk, leg.NumberOfStocks, leg.MiniQuantity, leg.Leverage, leg.MiniProfit, status)
+	}
+	tw.Flush()
+
+	fmt.Fprintf(w, "\nTotal risk budget: %s\n", plan.TotalRisk)
+	fmt.Fprintf(w, "Aggregate expected profit at take-profit: %s\n", plan.AggregateProfit)
+}
@@ -0,0 +1,112 @@
+// Package pricing fetches current market quotes for an underlying stock and
+// its derivative, so the CLI does not have to be driven entirely by manual
+// -price and -derivatePrice flags.
+package pricing
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Quote is a minimal snapshot of a symbol's market data, modeled after the
+// fields piquette/finance-go exposes from Yahoo Finance's quote endpoint.
+type Quote struct {
+	Symbol             string
+	RegularMarketPrice float64
+	Bid                float64
+	Ask                float64
+	MarketState        string
+}
+
+// QuoteProvider fetches a Quote for a given symbol.
+type QuoteProvider interface {
+	GetQuote(symbol string) (*Quote, error)
+}
+
+// YahooQuoteProvider implements QuoteProvider against Yahoo Finance's
+// public quote endpoint.
+type YahooQuoteProvider struct {
+	client  *http.Client
+	baseURL string
+}
+
+// NewYahooQuoteProvider returns a YahooQuoteProvider with sensible defaults.
+func NewYahooQuoteProvider() *YahooQuoteProvider {
+	return &YahooQuoteProvider{
+		client:  &http.Client{Timeout: 10 * time.Second},
+		baseURL: "https://query1.finance.yahoo.com/v7/finance/quote",
+	}
+}
+
+type yahooQuoteResponse struct {
+	QuoteResponse struct {
+		Result []struct {
+			Symbol             string  `json:"symbol"`
+			RegularMarketPrice float64 `json:"regularMarketPrice"`
+			Bid                float64 `json:"bid"`
+			Ask                float64 `json:"ask"`
+			MarketState        string  `json:"marketState"`
+		} `json:"result"`
+		Error interface{} `json:"error"`
+	} `json:"quoteResponse"`
+}
+
+// GetQuote fetches the latest quote for symbol from Yahoo Finance.
+func (y *YahooQuoteProvider) GetQuote(symbol string) (*Quote, error) {
+	req, err := http.NewRequest(http.MethodGet, y.baseURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("pricing: building request for %s: %w", symbol, err)
+	}
+	q := req.URL.Query()
+	q.Set("symbols", symbol)
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := y.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("pricing: fetching quote for %s: %w", symbol, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("pricing: quote endpoint returned status %d for %s", resp.StatusCode, symbol)
+	}
+
+	var parsed yahooQuoteResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("pricing: decoding quote response for %s: %w", symbol, err)
+	}
+
+	if len(parsed.QuoteResponse.Result) == 0 {
+		return nil, fmt.Errorf("pricing: no quote found for %s", symbol)
+	}
+
+	r := parsed.QuoteResponse.Result[0]
+	return &Quote{
+		Symbol:             r.Symbol,
+		RegularMarketPrice: r.RegularMarketPrice,
+		Bid:                r.Bid,
+		Ask:                r.Ask,
+		MarketState:        r.MarketState,
+	}, nil
+}
+
+// FallbackPrice returns the quote's regular market price when the market is
+// open, or the mid of bid/ask when it is closed and no trade price is fresh.
+// If neither is available it falls back to manual.
+func FallbackPrice(q *Quote, manual float64) float64 {
+	if q == nil {
+		return manual
+	}
+	if q.MarketState == "REGULAR" && q.RegularMarketPrice > 0 {
+		return q.RegularMarketPrice
+	}
+	if q.Bid > 0 && q.Ask > 0 {
+		return (q.Bid + q.Ask) / 2
+	}
+	if q.RegularMarketPrice > 0 {
+		return q.RegularMarketPrice
+	}
+	return manual
+}
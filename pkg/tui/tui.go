@@ -0,0 +1,235 @@
+// Package tui is an interactive Bubble Tea front-end for the mini-future
+// sizing formulas: it lets the user edit price, stop, risk, finance level,
+// parity and derivative price and see every derived number, plus a P&L
+// curve, update live.
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/0dayfall/minifuture/pkg/calc"
+	"github.com/0dayfall/minifuture/pkg/fixedpoint"
+	"github.com/0dayfall/minifuture/pkg/instrument"
+)
+
+var (
+	labelStyle   = lipgloss.NewStyle().Bold(true).Width(14)
+	focusedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("212"))
+	panelStyle   = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
+	helpStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+)
+
+type field int
+
+const (
+	fieldPrice field = iota
+	fieldStop
+	fieldRisk
+	fieldFinanceLevel
+	fieldParity
+	fieldDerivatePrice
+	fieldCount
+)
+
+var fieldNames = [fieldCount]string{
+	fieldPrice:         "Price",
+	fieldStop:          "Stop",
+	fieldRisk:          "Risk",
+	fieldFinanceLevel:  "Finance",
+	fieldParity:        "Parity",
+	fieldDerivatePrice: "Mini Price",
+}
+
+// Model is the Bubble Tea model driving the scenario explorer.
+type Model struct {
+	inputs [fieldCount]string
+	focus  field
+	err    string
+}
+
+// NewModel builds a Model seeded with the CLI's starting values.
+func NewModel(price, stop, risk, financeLevel, parity, derivatePrice fixedpoint.Value) Model {
+	m := Model{}
+	m.inputs[fieldPrice] = price.String()
+	m.inputs[fieldStop] = stop.String()
+	m.inputs[fieldRisk] = risk.String()
+	m.inputs[fieldFinanceLevel] = financeLevel.String()
+	m.inputs[fieldParity] = fmt.Sprintf("%v", parity)
+	m.inputs[fieldDerivatePrice] = derivatePrice.String()
+	return m
+}
+
+// Init implements tea.Model.
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyCtrlC, tea.KeyEsc:
+		return m, tea.Quit
+	case tea.KeyTab, tea.KeyDown:
+		m.focus = (m.focus + 1) % fieldCount
+	case tea.KeyShiftTab, tea.KeyUp:
+		m.focus = (m.focus - 1 + fieldCount) % fieldCount
+	case tea.KeyBackspace:
+		cur := m.inputs[m.focus]
+		if len(cur) > 0 {
+			m.inputs[m.focus] = cur[:len(cur)-1]
+		}
+	case tea.KeyRunes:
+		m.inputs[m.focus] += string(keyMsg.Runes)
+	}
+
+	return m, nil
+}
+
+// View implements tea.Model.
+func (m Model) View() string {
+	var b strings.Builder
+
+	for f := field(0); f < fieldCount; f++ {
+		label := labelStyle.Render(fieldNames[f] + ":")
+		value := m.inputs[f]
+		if f == m.focus {
+			value = focusedStyle.Render(value + "_")
+		}
+		b.WriteString(label + " " + value + "\n")
+	}
+
+	scenario, err := m.compute()
+	if err != nil {
+		b.WriteString("\n" + helpStyle.Render("waiting for valid numbers: "+err.Error()) + "\n")
+	} else {
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("Stock> Shares: %s  Take profit: %s\n", scenario.numberOfStocks, scenario.takeProfit))
+		b.WriteString(fmt.Sprintf("Mini>  Leverage: %s  Stop: %s  Qty: %s  Profit: %s\n",
+			scenario.leverage, scenario.miniStop, scenario.miniQty, scenario.miniProfit))
+		b.WriteString("\n" + panelStyle.Render(renderPnLCurve(scenario)) + "\n")
+	}
+
+	b.WriteString("\n" + helpStyle.Render("tab/shift+tab to move between fields, esc to quit"))
+	return b.String()
+}
+
+type scenario struct {
+	price, stop, risk, financeLevel, derivatePrice fixedpoint.Value
+	parity                                         float64
+	numberOfStocks, takeProfit                     fixedpoint.Value
+	leverage, miniStop, miniQty, miniProfit        fixedpoint.Value
+}
+
+func (m Model) compute() (*scenario, error) {
+	price, err := fixedpoint.NewFromString(m.inputs[fieldPrice])
+	if err != nil {
+		return nil, fmt.Errorf("price: %w", err)
+	}
+	stop, err := fixedpoint.NewFromString(m.inputs[fieldStop])
+	if err != nil {
+		return nil, fmt.Errorf("stop: %w", err)
+	}
+	risk, err := fixedpoint.NewFromString(m.inputs[fieldRisk])
+	if err != nil {
+		return nil, fmt.Errorf("risk: %w", err)
+	}
+	financeLevel, err := fixedpoint.NewFromString(m.inputs[fieldFinanceLevel])
+	if err != nil {
+		return nil, fmt.Errorf("finance: %w", err)
+	}
+	parity, err := strconv.ParseFloat(m.inputs[fieldParity], 64)
+	if err != nil {
+		return nil, fmt.Errorf("parity: %w", err)
+	}
+	derivatePrice, err := fixedpoint.NewFromString(m.inputs[fieldDerivatePrice])
+	if err != nil {
+		return nil, fmt.Errorf("mini price: %w", err)
+	}
+
+	inst := &instrument.Instrument{ParityRatio: parity, QuoteCurrency: "SEK", FinanceCurrency: "SEK", Multiplier: 1}
+	lotSize := fixedpoint.NewFromFloat(1)
+	tickSize := fixedpoint.NewFromFloat(0.01)
+
+	s := &scenario{price: price, stop: stop, risk: risk, financeLevel: financeLevel, derivatePrice: derivatePrice, parity: parity}
+	s.numberOfStocks = calc.NumberOfStocks(risk, price, stop, lotSize)
+	s.takeProfit = calc.TakeProfit(price, stop)
+	s.leverage = calc.MiniLeverage(price, financeLevel, inst, 1)
+	s.miniStop = calc.MiniStop(stop, financeLevel, tickSize, inst, 1)
+	s.miniQty = calc.MiniQuantity(risk, derivatePrice, s.miniStop, lotSize)
+	s.miniProfit = calc.MiniProfit(s.takeProfit, financeLevel, derivatePrice, s.miniQty, inst, 1)
+	return s, nil
+}
+
+// renderPnLCurve draws an ASCII P&L curve across a range of underlying
+// prices around the current price, overlaying the mini position ('#')
+// against the plain stock position ('*') so the two outcomes can be
+// compared side by side; '+' marks where both curves reach the same row.
+func renderPnLCurve(s *scenario) string {
+	const steps = 21
+	const height = 10
+
+	low := s.price.Float64() * 0.9
+	high := s.price.Float64() * 1.1
+	step := (high - low) / float64(steps-1)
+
+	miniProfits := make([]float64, steps)
+	stockProfits := make([]float64, steps)
+	minProfit, maxProfit := 0.0, 0.0
+	for i := 0; i < steps; i++ {
+		underlying := low + step*float64(i)
+		miniAtPrice := (underlying - s.financeLevel.Float64()) / 100 * s.parity
+		miniProfits[i] = (miniAtPrice * s.miniQty.Float64()) - (s.derivatePrice.Float64() * s.miniQty.Float64())
+		stockProfits[i] = (underlying - s.price.Float64()) * s.numberOfStocks.Float64()
+
+		for _, p := range []float64{miniProfits[i], stockProfits[i]} {
+			if p < minProfit {
+				minProfit = p
+			}
+			if p > maxProfit {
+				maxProfit = p
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Stock (*) vs Mini (#) P&L vs underlying [%.2f .. %.2f]\n", low, high))
+	rng := maxProfit - minProfit
+	if rng == 0 {
+		rng = 1
+	}
+	for row := height; row >= 0; row-- {
+		threshold := minProfit + rng*float64(row)/float64(height)
+		for i := 0; i < steps; i++ {
+			mini := miniProfits[i] >= threshold
+			stock := stockProfits[i] >= threshold
+			switch {
+			case mini && stock:
+				b.WriteString("+")
+			case mini:
+				b.WriteString("#")
+			case stock:
+				b.WriteString("*")
+			default:
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Run starts the interactive Bubble Tea program.
+func Run(m Model) error {
+	_, err := tea.NewProgram(m).Run()
+	return err
+}